@@ -10,8 +10,10 @@ package main
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
 	"os/user"
 	"path/filepath"
 	"runtime"
@@ -38,6 +40,62 @@ func RunAtExit(fn func()) {
 	atExitFuncs = append(atExitFuncs, fn)
 }
 
+// interruptMutex is used to modify the interrupted flag and the prompt.
+var interruptMutex sync.Mutex
+
+// interrupted is true if an interrupt signal has been received since the
+// last line of input was read.
+var interrupted bool
+
+// prompt is the prompt most recently displayed to the user, which is shown
+// again after an interrupt has been reported.
+var prompt string
+
+// signalCatcher waits for the interrupt signal (e.g. Ctrl-c) on the given
+// channel. The first interrupt returns the user to the prompt, while a
+// second interrupt that arrives before any further input is read causes
+// the program to exit via the Exit() function, so the registered exit
+// functions are invoked.
+func signalCatcher(ch <-chan os.Signal) {
+	for range ch {
+		if recordInterrupt() {
+			fmt.Println("\nGoodbye")
+			Exit()
+		}
+		fmt.Println("\nInterrupted, press Ctrl-c again to exit")
+		interruptMutex.Lock()
+		fmt.Print(prompt)
+		interruptMutex.Unlock()
+	}
+}
+
+// recordInterrupt notes that an interrupt signal was received, returning
+// true if this is the second interrupt since input was last read.
+func recordInterrupt() bool {
+	interruptMutex.Lock()
+	defer interruptMutex.Unlock()
+	again := interrupted
+	interrupted = true
+	return again
+}
+
+// clearInterrupt resets the interrupted flag, typically after a line of
+// input has been read, such that a subsequent interrupt will not exit.
+func clearInterrupt() {
+	interruptMutex.Lock()
+	defer interruptMutex.Unlock()
+	interrupted = false
+}
+
+// showPrompt displays the given prompt and records it so that it may be
+// displayed again after an interrupt.
+func showPrompt(p string) {
+	interruptMutex.Lock()
+	defer interruptMutex.Unlock()
+	prompt = p
+	fmt.Print(p)
+}
+
 // Exit invokes the functions registered to be called prior to exiting, then
 // invokes os.Exit() to exit from the program. This function should be called
 // instead of os.Exit() in all but the most extreme cases.
@@ -53,10 +111,15 @@ func Exit() {
 func main() {
 	// while not a guarantee, at least try to exit cleanly
 	defer Exit()
+	// register for interrupts before anything else, lest an early
+	// signal kill the process without running the exit functions
+	sigch := make(chan os.Signal, 1)
+	signal.Notify(sigch, os.Interrupt)
+	go signalCatcher(sigch)
 	setupLogging()
 	logSysInfo()
 	welmsg := `Welcome to GoSwat! To get started, try the ':help' command.
-Use ':exit' or Ctrl-c to exit the debugger.`
+Use ':exit' or Ctrl-c twice to exit the debugger.`
 	//Startup commands can be placed in ".goswatrc" in ~ or .`
 	fmt.Println(welmsg)
 	// TODO: initialize the scheme environment
@@ -72,11 +135,15 @@ func repl() {
 	// the following will work on any system, but it is rather crude
 	stdin := bufio.NewReader(os.Stdin)
 	for {
-		fmt.Print("(goswat) ")
+		showPrompt("(goswat) ")
 		input, err := stdin.ReadString(10)
-		if err != nil {
+		if err == io.EOF {
+			fmt.Println("\nGoodbye")
+			Exit()
+		} else if err != nil {
 			fmt.Println(err)
 		} else {
+			clearInterrupt()
 			input = strings.TrimSpace(input)
 			// process the command
 			if input == ":exit" {
@@ -101,11 +168,15 @@ func repl() {
 func lispRepl() {
 	stdin := bufio.NewReader(os.Stdin)
 	for {
-		fmt.Print("(lisp) ")
+		showPrompt("(lisp) ")
 		input, err := stdin.ReadString(10)
-		if err != nil {
+		if err == io.EOF {
+			fmt.Println()
+			return
+		} else if err != nil {
 			fmt.Println(err)
 		} else {
+			clearInterrupt()
 			input = strings.TrimSpace(input)
 			// process the command
 			if input == ":exit" {
@@ -186,23 +257,3 @@ func logSysInfo() {
 	}
 	log.Println(header)
 }
-
-// TODO: use the following to capture interrupt signal (e.g. Ctrl-c)
-// import (
-//     "fmt"
-//     "os"
-//     "os/signal"
-//     "runtime"
-//     "syscall"
-// )
-// func signalCatcher() {
-//     ch := make(chan os.Signal)
-//     signal.Notify(ch, syscall.SIGINT)
-//     <-ch
-//     fmt.Println("CTRL-C; exiting")
-//     os.Exit(0)
-// }
-// func main() {
-//     go signalCatcher()
-//     // Go about doing stuff, avoid tight loops, or ensure GOMAXPROCS is > 1
-// }
@@ -0,0 +1,30 @@
+//
+// Copyright 2012-2013 Nathan Fiedler. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+//
+
+package main
+
+import (
+	"testing"
+)
+
+func TestRecordInterrupt(t *testing.T) {
+	clearInterrupt()
+	if recordInterrupt() {
+		t.Error("first interrupt should not signal exit")
+	}
+	if !recordInterrupt() {
+		t.Error("second interrupt should signal exit")
+	}
+	// reading input resets the state
+	clearInterrupt()
+	if recordInterrupt() {
+		t.Error("interrupt after input should not signal exit")
+	}
+	if !recordInterrupt() {
+		t.Error("second interrupt after input should signal exit")
+	}
+	clearInterrupt()
+}